@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/silvisea/bank-app/pkg/bank"
+)
+
+// Основная логика приложения
+func main() {
+	storageKind := flag.String("storage", "memory", "бэкенд хранилища: memory или file")
+	storagePath := flag.String("storage-path", "./data/accounts", "каталог для файлового хранилища (при -storage=file)")
+	sessionTTL := flag.Duration("session-ttl", 5*time.Minute, "время жизни сессии после ввода PIN")
+	adminAccountID := flag.String("admin-account-id", "", "ID счета, управляющего курсами обмена валют")
+	flag.Parse()
+
+	var storage bank.Storage
+	var journal bank.Journal
+	switch *storageKind {
+	case "file":
+		fileStorage, err := bank.NewFileStorage(*storagePath)
+		if err != nil {
+			fmt.Printf("Ошибка инициализации файлового хранилища: %v\n", err)
+			os.Exit(1)
+		}
+		storage = fileStorage
+
+		fileJournal, err := bank.NewFileJournal(filepath.Join(*storagePath, "journal.log"))
+		if err != nil {
+			fmt.Printf("Ошибка инициализации журнала: %v\n", err)
+			os.Exit(1)
+		}
+		journal = fileJournal
+	case "memory":
+		storage = bank.NewMemoryStorage()
+		journal = bank.NewMemoryJournal()
+	default:
+		fmt.Printf("Неизвестный тип хранилища: %s\n", *storageKind)
+		os.Exit(1)
+	}
+
+	if err := bank.ReplayJournal(journal, storage); err != nil {
+		fmt.Printf("Ошибка восстановления журнала: %v\n", err)
+		os.Exit(1)
+	}
+
+	currencyRegistry := bank.NewCurrencyRegistry()
+	currencyRegistry.Register(bank.Currency{Symbol: "USD", Decimals: 2, DisplayName: "Доллар США"})
+	currencyRegistry.Register(bank.Currency{Symbol: "EUR", Decimals: 2, DisplayName: "Евро"})
+	swapConfig := bank.NewSwapConfig(*adminAccountID)
+
+	scanner := bufio.NewScanner(os.Stdin)
+
+	fmt.Println("=== БАНКОВСКОЕ ПРИЛОЖЕНИЕ ===")
+
+	for {
+		showMainMenu()
+		fmt.Print("Выберите действие: ")
+
+		scanner.Scan()
+		choice := scanner.Text()
+
+		switch choice {
+		case "1":
+			createAccount(scanner, storage)
+		case "2":
+			selectAccount(scanner, storage, journal, currencyRegistry, swapConfig, *sessionTTL)
+		case "3":
+			runAdminMenu(scanner, storage, swapConfig, currencyRegistry, *sessionTTL)
+		case "4":
+			fmt.Println("Выход из программы...")
+			return
+		default:
+			fmt.Println("Неверный выбор. Попробуйте снова.")
+		}
+	}
+}
+
+func showMainMenu() {
+	fmt.Println("\n1. Создать счет")
+	fmt.Println("2. Выбрать счет")
+	fmt.Println("3. Администрирование курсов валют")
+	fmt.Println("4. Выйти")
+}
+
+func createAccount(scanner *bufio.Scanner, storage bank.Storage) {
+	fmt.Print("Введите имя владельца счета: ")
+	scanner.Scan()
+	ownerName := scanner.Text()
+
+	if ownerName == "" {
+		fmt.Println("Имя владельца не может быть пустым")
+		return
+	}
+
+	fmt.Print("Придумайте PIN-код для счета: ")
+	scanner.Scan()
+	pin := scanner.Text()
+
+	if pin == "" {
+		fmt.Println("PIN-код не может быть пустым")
+		return
+	}
+
+	account := bank.NewAccount(ownerName)
+	account.SetPIN(pin)
+	if err := storage.SaveAccount(account); err != nil {
+		fmt.Printf("Ошибка создания счета: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Счет успешно создан! ID: %s\n", account.ID)
+}
+
+func selectAccount(scanner *bufio.Scanner, storage bank.Storage, journal bank.Journal, registry *bank.CurrencyRegistry, swapConfig *bank.SwapConfig, sessionTTL time.Duration) {
+	accounts, err := storage.GetAllAccounts()
+	if err != nil {
+		fmt.Printf("Ошибка получения счетов: %v\n", err)
+		return
+	}
+
+	if len(accounts) == 0 {
+		fmt.Println("Нет созданных счетов")
+		return
+	}
+
+	fmt.Println("\nДоступные счета:")
+	for i, acc := range accounts {
+		fmt.Printf("%d. %s (Владелец: %s, Баланс: %.2f)\n",
+			i+1, acc.ID, acc.OwnerName, acc.Balance)
+	}
+
+	fmt.Print("Выберите номер счета: ")
+	scanner.Scan()
+	choice, err := strconv.Atoi(scanner.Text())
+	if err != nil || choice < 1 || choice > len(accounts) {
+		fmt.Println("Неверный выбор")
+		return
+	}
+
+	selectedAccount := accounts[choice-1]
+
+	const maxPINAttempts = 3
+	var session *bank.Session
+	for attempt := 1; attempt <= maxPINAttempts; attempt++ {
+		fmt.Print("Введите PIN-код: ")
+		scanner.Scan()
+		pin := scanner.Text()
+
+		if selectedAccount.CheckPIN(pin) {
+			session = bank.NewSession(sessionTTL)
+			break
+		}
+
+		fmt.Printf("Неверный PIN-код (попытка %d из %d)\n", attempt, maxPINAttempts)
+	}
+
+	if session == nil {
+		fmt.Println("Превышено число попыток ввода PIN-кода")
+		return
+	}
+
+	accountService := bank.NewAccountService(selectedAccount, storage, journal, session)
+	swapService := bank.NewSwapService(selectedAccount, storage, registry, swapConfig, session)
+	runAccountMenu(scanner, accountService, swapService, storage, selectedAccount)
+}
+
+func runAccountMenu(scanner *bufio.Scanner, accountService bank.AccountService, swapService bank.SwapService, storage bank.Storage, account *bank.Account) {
+	for {
+		showAccountMenu(account.OwnerName)
+		fmt.Print("Выберите действие: ")
+
+		scanner.Scan()
+		choice := scanner.Text()
+
+		switch choice {
+		case "1":
+			handleDeposit(scanner, accountService)
+		case "2":
+			handleWithdraw(scanner, accountService)
+		case "3":
+			handleTransfer(scanner, accountService, storage)
+		case "4":
+			handleShowBalance(accountService)
+		case "5":
+			handleGetStatement(accountService)
+		case "6":
+			handleSwap(scanner, swapService)
+		case "7":
+			return
+		default:
+			fmt.Println("Неверный выбор. Попробуйте снова.")
+		}
+	}
+}
+
+func showAccountMenu(ownerName string) {
+	fmt.Printf("\n=== СЧЕТ: %s ===\n", ownerName)
+	fmt.Println("1. Пополнить счет")
+	fmt.Println("2. Снять средства")
+	fmt.Println("3. Перевести другому счету")
+	fmt.Println("4. Просмотреть баланс")
+	fmt.Println("5. Получить выписку")
+	fmt.Println("6. Обменять валюту")
+	fmt.Println("7. Вернуться в главное меню")
+}
+
+func handleDeposit(scanner *bufio.Scanner, accountService bank.AccountService) {
+	amount, err := getAmountFromUser(scanner, "Введите сумму для пополнения: ")
+	if err != nil {
+		return
+	}
+
+	if err := accountService.Deposit(amount); err != nil {
+		fmt.Printf("Ошибка пополнения: %v\n", err)
+	} else {
+		fmt.Printf("Счет успешно пополнен на %.2f\n", amount)
+	}
+}
+func handleWithdraw(scanner *bufio.Scanner, accountService bank.AccountService) {
+	amount, err := getAmountFromUser(scanner, "Введите сумму для снятия: ")
+	if err != nil {
+		return
+	}
+
+	if err := accountService.Withdraw(amount); err != nil {
+		fmt.Printf("Ошибка снятия: %v\n", err)
+	} else {
+		fmt.Printf("Со счета успешно снято %.2f\n", amount)
+	}
+}
+
+func handleTransfer(scanner *bufio.Scanner, accountService bank.AccountService, storage bank.Storage) {
+	amount, err := getAmountFromUser(scanner, "Введите сумму для перевода: ")
+	if err != nil {
+		return
+	}
+
+	fmt.Print("Введите ID счета получателя: ")
+	scanner.Scan()
+	toAccountID := scanner.Text()
+
+	toAccount, err := storage.LoadAccount(toAccountID)
+	if err != nil {
+		fmt.Printf("Ошибка поиска счета: %v\n", err)
+		return
+	}
+
+	if err := accountService.Transfer(toAccount, amount); err != nil {
+		fmt.Printf("Ошибка перевода: %v\n", err)
+	} else {
+		fmt.Printf("Успешно переведено %.2f на счет %s\n", amount, toAccountID)
+	}
+}
+
+func handleShowBalance(accountService bank.AccountService) {
+	balance := accountService.GetBalance()
+	fmt.Printf("Текущий баланс: %.2f\n", balance)
+}
+
+func handleGetStatement(accountService bank.AccountService) {
+	statement := accountService.GetStatement()
+	fmt.Println(statement)
+}
+
+func handleSwap(scanner *bufio.Scanner, swapService bank.SwapService) {
+	fmt.Print("Из какой валюты (например, RUB, USD, EUR): ")
+	scanner.Scan()
+	from := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+
+	fmt.Print("В какую валюту: ")
+	scanner.Scan()
+	to := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+
+	fmt.Print("Введите сумму для обмена (0 — обменять весь остаток): ")
+	scanner.Scan()
+	amount, err := strconv.ParseFloat(scanner.Text(), 64)
+	if err != nil {
+		fmt.Println("Неверный формат суммы")
+		return
+	}
+
+	if amount == 0 {
+		err = swapService.SwapAll(from, to)
+	} else {
+		err = swapService.Swap(from, to, amount)
+	}
+
+	if err != nil {
+		fmt.Printf("Ошибка обмена валюты: %v\n", err)
+	} else {
+		fmt.Println("Обмен валюты выполнен успешно")
+	}
+}
+
+func runAdminMenu(scanner *bufio.Scanner, storage bank.Storage, swapConfig *bank.SwapConfig, registry *bank.CurrencyRegistry, sessionTTL time.Duration) {
+	fmt.Print("Введите ID счета администратора: ")
+	scanner.Scan()
+	adminAccountID := scanner.Text()
+
+	adminAccount, err := storage.LoadAccount(adminAccountID)
+	if err != nil {
+		fmt.Printf("Ошибка: счет администратора не найден: %v\n", err)
+		return
+	}
+
+	const maxPINAttempts = 3
+	var session *bank.Session
+	for attempt := 1; attempt <= maxPINAttempts; attempt++ {
+		fmt.Print("Введите PIN-код администратора: ")
+		scanner.Scan()
+		pin := scanner.Text()
+
+		if adminAccount.CheckPIN(pin) {
+			session = bank.NewSession(sessionTTL)
+			break
+		}
+
+		fmt.Printf("Неверный PIN-код (попытка %d из %d)\n", attempt, maxPINAttempts)
+	}
+
+	if session == nil {
+		fmt.Println("Превышено число попыток ввода PIN-кода")
+		return
+	}
+
+	for {
+		fmt.Println("\n=== АДМИНИСТРИРОВАНИЕ КУРСОВ ВАЛЮТ ===")
+		fmt.Println("1. Предложить курс обмена")
+		fmt.Println("2. Подтвердить предложение")
+		fmt.Println("3. Показать предложения")
+		fmt.Println("4. Вернуться в главное меню")
+		fmt.Print("Выберите действие: ")
+
+		scanner.Scan()
+		choice := scanner.Text()
+
+		switch choice {
+		case "1":
+			handleProposeSwapRate(scanner, swapConfig, registry, adminAccountID, session)
+		case "2":
+			handleExecuteSwapProposal(scanner, swapConfig, adminAccountID, session)
+		case "3":
+			handleListSwapProposals(swapConfig)
+		case "4":
+			return
+		default:
+			fmt.Println("Неверный выбор. Попробуйте снова.")
+		}
+	}
+}
+
+func handleProposeSwapRate(scanner *bufio.Scanner, swapConfig *bank.SwapConfig, registry *bank.CurrencyRegistry, adminAccountID string, session *bank.Session) {
+	fmt.Print("Из какой валюты: ")
+	scanner.Scan()
+	from := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+
+	fmt.Print("В какую валюту: ")
+	scanner.Scan()
+	to := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+
+	if _, ok := registry.Get(from); !ok {
+		fmt.Printf("Ошибка: валюта %s не зарегистрирована\n", from)
+		return
+	}
+	if _, ok := registry.Get(to); !ok {
+		fmt.Printf("Ошибка: валюта %s не зарегистрирована\n", to)
+		return
+	}
+
+	fmt.Print("Введите курс обмена: ")
+	scanner.Scan()
+	rate, err := strconv.ParseFloat(scanner.Text(), 64)
+	if err != nil {
+		fmt.Println("Неверный формат курса")
+		return
+	}
+
+	proposalID, err := swapConfig.ProposeSwapRate(adminAccountID, session, from, to, rate)
+	if err != nil {
+		fmt.Printf("Ошибка предложения курса: %v\n", err)
+		return
+	}
+	fmt.Printf("Предложение создано, ID: %s\n", proposalID)
+}
+
+func handleExecuteSwapProposal(scanner *bufio.Scanner, swapConfig *bank.SwapConfig, adminAccountID string, session *bank.Session) {
+	fmt.Print("Введите ID предложения: ")
+	scanner.Scan()
+	proposalID := scanner.Text()
+
+	if err := swapConfig.ExecuteSwapProposal(adminAccountID, session, proposalID); err != nil {
+		fmt.Printf("Ошибка подтверждения предложения: %v\n", err)
+		return
+	}
+	fmt.Println("Предложение подтверждено, курс обновлен")
+}
+
+func handleListSwapProposals(swapConfig *bank.SwapConfig) {
+	proposals := swapConfig.Proposals()
+	if len(proposals) == 0 {
+		fmt.Println("Предложений пока нет")
+		return
+	}
+
+	for _, p := range proposals {
+		status := "ожидает подтверждения"
+		if p.Executed {
+			status = "подтверждено"
+		}
+		fmt.Printf("- %s: %s -> %s по курсу %.6f (%s)\n", p.ID, p.From, p.To, p.Rate, status)
+	}
+}
+
+func getAmountFromUser(scanner *bufio.Scanner, prompt string) (float64, error) {
+	fmt.Print(prompt)
+	scanner.Scan()
+	amount, err := strconv.ParseFloat(scanner.Text(), 64)
+	if err != nil {
+		fmt.Println("Неверный формат суммы")
+		return 0, err
+	}
+	return amount, nil
+}
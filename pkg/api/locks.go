@@ -0,0 +1,58 @@
+package api
+
+import (
+	"sort"
+	"sync"
+)
+
+// accountLocks serializes AccountService calls per account so that concurrent
+// HTTP requests against the same account don't race through the
+// non-locking bank.AccountServiceImpl.
+type accountLocks struct {
+	mutex sync.Mutex
+	byID  map[string]*sync.Mutex
+}
+
+func newAccountLocks() *accountLocks {
+	return &accountLocks{byID: make(map[string]*sync.Mutex)}
+}
+
+func (l *accountLocks) lockFor(accountID string) *sync.Mutex {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	m, ok := l.byID[accountID]
+	if !ok {
+		m = &sync.Mutex{}
+		l.byID[accountID] = m
+	}
+	return m
+}
+
+// lock locks every distinct account in accountIDs, always in sorted order, so
+// concurrent requests touching the same pair of accounts can't deadlock. It
+// returns a function that releases all of them.
+func (l *accountLocks) lock(accountIDs ...string) func() {
+	unique := make(map[string]*sync.Mutex, len(accountIDs))
+	for _, id := range accountIDs {
+		if _, ok := unique[id]; !ok {
+			unique[id] = l.lockFor(id)
+		}
+	}
+
+	ordered := make([]string, 0, len(unique))
+	for id := range unique {
+		ordered = append(ordered, id)
+	}
+	sort.Strings(ordered)
+
+	for _, id := range ordered {
+		unique[id].Lock()
+	}
+
+	return func() {
+		for _, id := range ordered {
+			unique[id].Unlock()
+		}
+	}
+}
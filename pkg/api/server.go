@@ -0,0 +1,334 @@
+// Package api exposes pkg/bank over HTTP so the banking core can be driven
+// by something other than the cmd/bank-cli REPL.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/silvisea/bank-app/pkg/bank"
+)
+
+// requestSessionTTL is long enough to cover one HTTP request's handling but
+// short enough that a leaked session can't be replayed later.
+const requestSessionTTL = time.Minute
+
+// defaultPageSize and maxPageSize bound GET .../transactions pagination.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Server adapts bank.Storage/bank.Journal to an HTTP transport.
+type Server struct {
+	storage bank.Storage
+	journal bank.Journal
+	locks   *accountLocks
+	mux     *http.ServeMux
+}
+
+// NewServer builds a Server backed by storage and journal. Pass
+// bank.NewMemoryStorage() and bank.NewMemoryJournal() for tests.
+func NewServer(storage bank.Storage, journal bank.Journal) *Server {
+	s := &Server{
+		storage: storage,
+		journal: journal,
+		locks:   newAccountLocks(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /accounts", s.handleCreateAccount)
+	mux.HandleFunc("POST /accounts/{id}/deposit", s.handleDeposit)
+	mux.HandleFunc("POST /accounts/{id}/withdraw", s.handleWithdraw)
+	mux.HandleFunc("POST /transfers", s.handleTransfer)
+	mux.HandleFunc("GET /accounts/{id}/balance", s.handleBalance)
+	mux.HandleFunc("GET /accounts/{id}/transactions", s.handleTransactions)
+	s.mux = mux
+
+	return s
+}
+
+// ServeHTTP lets Server be used directly with http.ListenAndServe or httptest.NewServer.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) accountService(account *bank.Account) bank.AccountService {
+	return bank.NewAccountService(account, s.storage, s.journal, bank.NewSession(requestSessionTTL))
+}
+
+// authenticatedService checks pin against account's stored PIN hash before
+// minting a session, so Withdraw/Transfer (which require a valid session)
+// can't be driven with nothing but a known account ID.
+func (s *Server) authenticatedService(account *bank.Account, pin string) (bank.AccountService, error) {
+	if !account.CheckPIN(pin) {
+		return nil, bank.ErrInvalidPIN
+	}
+	return bank.NewAccountService(account, s.storage, s.journal, bank.NewSession(requestSessionTTL)), nil
+}
+
+type createAccountRequest struct {
+	OwnerName string `json:"ownerName"`
+	PIN       string `json:"pin"`
+}
+
+type createAccountResponse struct {
+	ID        string `json:"id"`
+	OwnerName string `json:"ownerName"`
+}
+
+func (s *Server) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req createAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.OwnerName == "" || req.PIN == "" {
+		writeError(w, http.StatusBadRequest, errors.New("ownerName и pin обязательны"))
+		return
+	}
+
+	account := bank.NewAccount(req.OwnerName)
+	account.SetPIN(req.PIN)
+	if err := s.storage.SaveAccount(account); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createAccountResponse{ID: account.ID, OwnerName: account.OwnerName})
+}
+
+type amountRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type withdrawRequest struct {
+	Amount float64 `json:"amount"`
+	PIN    string  `json:"pin"`
+}
+
+type balanceResponse struct {
+	Balance float64 `json:"balance"`
+}
+
+func (s *Server) handleDeposit(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	unlock := s.locks.lock(id)
+	defer unlock()
+
+	account, err := s.storage.LoadAccount(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req amountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.accountService(account).Deposit(req.Amount); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, balanceResponse{Balance: account.Balance})
+}
+
+func (s *Server) handleWithdraw(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	unlock := s.locks.lock(id)
+	defer unlock()
+
+	account, err := s.storage.LoadAccount(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req withdrawRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	service, err := s.authenticatedService(account, req.PIN)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := service.Withdraw(req.Amount); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, balanceResponse{Balance: account.Balance})
+}
+
+type transferRequest struct {
+	FromAccountID string  `json:"fromAccountId"`
+	ToAccountID   string  `json:"toAccountId"`
+	Amount        float64 `json:"amount"`
+	PIN           string  `json:"pin"`
+}
+
+func (s *Server) handleTransfer(w http.ResponseWriter, r *http.Request) {
+	var req transferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	unlock := s.locks.lock(req.FromAccountID, req.ToAccountID)
+	defer unlock()
+
+	from, err := s.storage.LoadAccount(req.FromAccountID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	to, err := s.storage.LoadAccount(req.ToAccountID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	service, err := s.authenticatedService(from, req.PIN)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := service.Transfer(to, req.Amount); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, balanceResponse{Balance: from.Balance})
+}
+
+func (s *Server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	unlock := s.locks.lock(id)
+	defer unlock()
+
+	account, err := s.storage.LoadAccount(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, balanceResponse{Balance: account.Balance})
+}
+
+type transactionDTO struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Amount      float64   `json:"amount"`
+	Timestamp   time.Time `json:"timestamp"`
+	Description string    `json:"description"`
+}
+
+type cursorInfo struct {
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	HasMore  bool    `json:"hasMore"`
+}
+
+type transactionsResponse struct {
+	Data   []transactionDTO `json:"data"`
+	Cursor cursorInfo       `json:"cursor"`
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	unlock := s.locks.lock(id)
+	defer unlock()
+
+	account, err := s.storage.LoadAccount(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	offset, pageSize, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	transactions := account.Transactions
+	start := offset
+	if start > len(transactions) {
+		start = len(transactions)
+	}
+	end := start + pageSize
+	if end > len(transactions) {
+		end = len(transactions)
+	}
+	page := transactions[start:end]
+
+	data := make([]transactionDTO, 0, len(page))
+	for _, tx := range page {
+		data = append(data, transactionDTO{
+			ID:          tx.ID,
+			Type:        string(tx.Type),
+			Amount:      tx.Amount,
+			Timestamp:   tx.Timestamp,
+			Description: tx.Description,
+		})
+	}
+
+	hasMore := end < len(transactions)
+	var next, previous *string
+	if hasMore {
+		n := strconv.Itoa(end)
+		next = &n
+	}
+	if start > 0 {
+		p := strconv.Itoa(max(0, start-pageSize))
+		previous = &p
+	}
+
+	writeJSON(w, http.StatusOK, transactionsResponse{
+		Data:   data,
+		Cursor: cursorInfo{Next: next, Previous: previous, HasMore: hasMore},
+	})
+}
+
+func parsePagination(r *http.Request) (offset int, pageSize int, err error) {
+	pageSize = defaultPageSize
+	if raw := r.URL.Query().Get("pageSize"); raw != "" {
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize <= 0 {
+			return 0, 0, errors.New("pageSize должен быть положительным числом")
+		}
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+	}
+
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		offset, err = strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("cursor должен быть неотрицательным числом")
+		}
+	}
+
+	return offset, pageSize, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
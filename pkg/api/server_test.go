@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/silvisea/bank-app/pkg/bank"
+)
+
+func postJSON(t *testing.T, url string, body any) *http.Response {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("не удалось сериализовать тело запроса: %v", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("запрос к %s не удался: %v", url, err)
+	}
+	return resp
+}
+
+func decodeJSON(t *testing.T, resp *http.Response, v any) {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("не удалось разобрать тело ответа: %v", err)
+	}
+}
+
+func TestServerDepositWithdrawTransfer(t *testing.T) {
+	server := NewServer(bank.NewMemoryStorage(), bank.NewMemoryJournal())
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	var alice createAccountResponse
+	resp := postJSON(t, ts.URL+"/accounts", createAccountRequest{OwnerName: "Alice", PIN: "1234"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("ожидался статус 201 при создании счета, получен %d", resp.StatusCode)
+	}
+	decodeJSON(t, resp, &alice)
+
+	var bob createAccountResponse
+	resp = postJSON(t, ts.URL+"/accounts", createAccountRequest{OwnerName: "Bob", PIN: "5678"})
+	decodeJSON(t, resp, &bob)
+
+	resp = postJSON(t, ts.URL+"/accounts/"+alice.ID+"/deposit", amountRequest{Amount: 1000})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ожидался статус 200 при пополнении, получен %d", resp.StatusCode)
+	}
+	var balance balanceResponse
+	decodeJSON(t, resp, &balance)
+	if balance.Balance != 1000 {
+		t.Fatalf("ожидался баланс 1000 после пополнения, получен %.2f", balance.Balance)
+	}
+
+	resp = postJSON(t, ts.URL+"/accounts/"+alice.ID+"/withdraw", withdrawRequest{Amount: 200, PIN: "1234"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ожидался статус 200 при снятии, получен %d", resp.StatusCode)
+	}
+	decodeJSON(t, resp, &balance)
+	if balance.Balance != 800 {
+		t.Fatalf("ожидался баланс 800 после снятия, получен %.2f", balance.Balance)
+	}
+
+	resp = postJSON(t, ts.URL+"/transfers", transferRequest{FromAccountID: alice.ID, ToAccountID: bob.ID, Amount: 300, PIN: "1234"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ожидался статус 200 при переводе, получен %d", resp.StatusCode)
+	}
+	decodeJSON(t, resp, &balance)
+	if balance.Balance != 500 {
+		t.Fatalf("ожидался баланс 500 у отправителя после перевода, получен %.2f", balance.Balance)
+	}
+
+	resp, err := http.Get(ts.URL + "/accounts/" + bob.ID + "/balance")
+	if err != nil {
+		t.Fatalf("запрос баланса получателя не удался: %v", err)
+	}
+	decodeJSON(t, resp, &balance)
+	if balance.Balance != 300 {
+		t.Fatalf("ожидался баланс получателя 300, получен %.2f", balance.Balance)
+	}
+
+	resp, err = http.Get(ts.URL + "/accounts/" + alice.ID + "/transactions?pageSize=2")
+	if err != nil {
+		t.Fatalf("запрос истории транзакций не удался: %v", err)
+	}
+	var page transactionsResponse
+	decodeJSON(t, resp, &page)
+	if len(page.Data) != 2 {
+		t.Fatalf("ожидалось 2 транзакции на странице, получено %d", len(page.Data))
+	}
+	if !page.Cursor.HasMore {
+		t.Fatalf("ожидался hasMore=true, так как у счета 3 транзакции")
+	}
+}
+
+func TestServerWithdrawAndTransferRejectWrongPIN(t *testing.T) {
+	server := NewServer(bank.NewMemoryStorage(), bank.NewMemoryJournal())
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	var alice createAccountResponse
+	resp := postJSON(t, ts.URL+"/accounts", createAccountRequest{OwnerName: "Alice", PIN: "1234"})
+	decodeJSON(t, resp, &alice)
+
+	var bob createAccountResponse
+	resp = postJSON(t, ts.URL+"/accounts", createAccountRequest{OwnerName: "Bob", PIN: "5678"})
+	decodeJSON(t, resp, &bob)
+
+	resp = postJSON(t, ts.URL+"/accounts/"+alice.ID+"/deposit", amountRequest{Amount: 1000})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ожидался статус 200 при пополнении, получен %d", resp.StatusCode)
+	}
+
+	resp = postJSON(t, ts.URL+"/accounts/"+alice.ID+"/withdraw", withdrawRequest{Amount: 200, PIN: "0000"})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("снятие с неверным PIN: ожидался статус 401, получен %d", resp.StatusCode)
+	}
+
+	resp = postJSON(t, ts.URL+"/transfers", transferRequest{FromAccountID: alice.ID, ToAccountID: bob.ID, Amount: 200})
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("перевод без PIN: ожидался статус 401, получен %d", resp.StatusCode)
+	}
+
+	resp, err := http.Get(ts.URL + "/accounts/" + alice.ID + "/balance")
+	if err != nil {
+		t.Fatalf("запрос баланса не удался: %v", err)
+	}
+	var balance balanceResponse
+	decodeJSON(t, resp, &balance)
+	if balance.Balance != 1000 {
+		t.Fatalf("баланс не должен был измениться после отклоненных операций, получен %.2f", balance.Balance)
+	}
+}
@@ -0,0 +1,684 @@
+package bank
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Кастомные ошибки
+var (
+	ErrInsufficientFunds   = errors.New("недостаточно средств на счете")
+	ErrInvalidAmount       = errors.New("некорректная сумма (отрицательная или нулевая)")
+	ErrAccountNotFound     = errors.New("счет не найден")
+	ErrSameAccountTransfer = errors.New("попытка перевода на тот же счёт")
+	ErrInvalidPIN          = errors.New("неверный PIN-код")
+	ErrSessionExpired      = errors.New("сессия истекла, требуется повторный вход")
+	ErrNotAContract        = errors.New("счет не является контрактом")
+	ErrUnknownCurrency     = errors.New("незарегистрированная валюта")
+	ErrInvalidSwapRate     = errors.New("некорректный курс обмена (должен быть положительным)")
+	ErrSwapRateUnavailable = errors.New("курс обмена для этой пары валют не установлен")
+	ErrNotAdmin            = errors.New("операция доступна только администратору")
+	ErrAdminNotConfigured  = errors.New("счет администратора не настроен")
+	ErrProposalNotFound    = errors.New("предложение по курсу не найдено")
+	ErrProposalExecuted    = errors.New("предложение по курсу уже исполнено")
+)
+
+// Интерфейсы
+type AccountService interface {
+	Deposit(amount float64) error
+	Withdraw(amount float64) error
+	Transfer(to *Account, amount float64) error
+	GetBalance() float64
+	GetStatement() string
+
+	// Call исполняет код контракта to, списывая с текущего счета плату за gas и
+	// возвращая неиспользованный остаток.
+	Call(to *Account, input []byte, gas uint64) ([]byte, error)
+	// DeployContract создает новый счет-контракт с кодом code, владельцем которого
+	// становится текущий счет.
+	DeployContract(code []byte) (*Account, error)
+}
+
+type Storage interface {
+	SaveAccount(account *Account) error
+	LoadAccount(accountID string) (*Account, error)
+	GetAllAccounts() ([]*Account, error)
+	// SaveAccounts сохраняет несколько счетов одним вызовом — так Transfer
+	// записывает обе ноги перевода вместе. MemoryStorage применяет их атомарно;
+	// FileStorage — нет: при сбое между переименованиями файлов часть счетов
+	// может остаться в прежнем состоянии.
+	SaveAccounts(accounts []*Account) error
+
+	// SaveContractStorage и LoadContractStorage хранят key/value-хранилище контракта
+	// отдельно от самого счета.
+	SaveContractStorage(accountID string, storage map[string][]byte) error
+	LoadContractStorage(accountID string) (map[string][]byte, error)
+}
+
+// Domain модели
+type TransactionType string
+
+const (
+	Deposit  TransactionType = "DEPOSIT"
+	Withdraw TransactionType = "WITHDRAW"
+	Transfer TransactionType = "TRANSFER"
+	Swap     TransactionType = "SWAP"
+)
+
+// BaseCurrencySymbol — валюта, которую счет хранит в поле Balance. Остальные
+// зарегистрированные валюты живут в Account.Balances.
+const BaseCurrencySymbol = "RUB"
+
+type Transaction struct {
+	ID          string
+	Type        TransactionType
+	Amount      float64
+	Timestamp   time.Time
+	Description string
+}
+
+type Account struct {
+	ID           string
+	OwnerName    string
+	Balance      float64
+	Transactions []Transaction
+
+	// PINHash защищает доступ к счету: PIN никогда не хранится в открытом виде,
+	// а хэшируется bcrypt'ом (соль и cost-фактор встроены в сам хэш).
+	PINHash []byte
+
+	// Code превращает счет в контракт: если Code не пуст, счет исполняет его
+	// при вызове Call. StorageRoot нигде не заполняется: key/value-хранилище
+	// контракта адресуется по ID счета (Storage.SaveContractStorage/LoadContractStorage).
+	Code        []byte
+	StorageRoot []byte
+
+	// Balances хранит остатки во всех валютах, кроме базовой (см. BaseCurrencySymbol,
+	// которая по-прежнему живет в поле Balance).
+	Balances map[string]float64
+}
+
+func NewAccount(ownerName string) *Account {
+	return &Account{
+		ID:           generateID(),
+		OwnerName:    ownerName,
+		Balance:      0.0,
+		Transactions: make([]Transaction, 0),
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("ACC%d", time.Now().UnixNano())
+}
+
+// generateContractPIN возвращает случайный PIN, непригодный для ручного
+// подбора или ввода — используется для счетов-контрактов, к которым никто
+// не должен иметь интерактивный доступ по PIN-коду. Ошибка CSPRNG не
+// игнорируется: отдать счету-контракту предсказуемый (например, нулевой)
+// PIN было бы опаснее, чем отказать в деплое.
+func generateContractPIN() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("не удалось сгенерировать PIN контракта: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func (a *Account) AddTransaction(tType TransactionType, amount float64, description string) {
+	transaction := Transaction{
+		ID:          fmt.Sprintf("TX%d", time.Now().UnixNano()),
+		Type:        tType,
+		Amount:      amount,
+		Timestamp:   time.Now(),
+		Description: description,
+	}
+	a.Transactions = append(a.Transactions, transaction)
+}
+
+// cloneAccount возвращает независимую копию account, включая его срез
+// Transactions и карту Balances. Используется там, где мутации нужно
+// применить к счету лишь после подтверждения их персиста (см. Transfer) —
+// тот же принцип, что vm.go применяет к балансам внутри VM.
+func cloneAccount(account *Account) *Account {
+	clone := *account
+	clone.Transactions = append([]Transaction(nil), account.Transactions...)
+	if account.Balances != nil {
+		clone.Balances = make(map[string]float64, len(account.Balances))
+		for currency, balance := range account.Balances {
+			clone.Balances[currency] = balance
+		}
+	}
+	return &clone
+}
+
+// balanceOf возвращает остаток счета в указанной валюте.
+func (a *Account) balanceOf(currency string) float64 {
+	if currency == BaseCurrencySymbol {
+		return a.Balance
+	}
+	return a.Balances[currency]
+}
+
+// addBalance прибавляет delta (может быть отрицательным) к остатку в указанной валюте.
+func (a *Account) addBalance(currency string, delta float64) {
+	if currency == BaseCurrencySymbol {
+		a.Balance += delta
+		return
+	}
+	if a.Balances == nil {
+		a.Balances = make(map[string]float64)
+	}
+	a.Balances[currency] += delta
+}
+
+// bcryptCost — стоимость хэширования PIN-кода. bcrypt сам встраивает соль и
+// стоимость в итоговый хэш, поэтому отдельное поле для соли не требуется.
+const bcryptCost = bcrypt.DefaultCost
+
+// SetPIN хэширует и сохраняет PIN-код счета с помощью bcrypt.
+func (a *Account) SetPIN(pin string) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcryptCost)
+	if err != nil {
+		// GenerateFromPassword не может завершиться ошибкой при допустимом cost,
+		// но лучше оставить счет без PIN, чем сохранить нулевой хэш.
+		return
+	}
+	a.PINHash = hash
+}
+
+// CheckPIN сравнивает введённый PIN с сохранённым хэшем. bcrypt.CompareHashAndPassword
+// работает за время, не зависящее от содержимого пароля, что исключает timing-атаки.
+func (a *Account) CheckPIN(pin string) bool {
+	if len(a.PINHash) == 0 {
+		// Счета, созданные без PIN (например, в старых файлах), доступны без проверки.
+		return true
+	}
+	return bcrypt.CompareHashAndPassword(a.PINHash, []byte(pin)) == nil
+}
+
+// Session — токен подтверждённого доступа к счету с ограниченным временем жизни.
+type Session struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// NewSession создает сессию с заданным временем жизни (TTL).
+func NewSession(ttl time.Duration) *Session {
+	tokenBytes := make([]byte, 16)
+	_, _ = rand.Read(tokenBytes)
+	return &Session{
+		Token:     hex.EncodeToString(tokenBytes),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// Valid сообщает, не истекла ли сессия.
+func (s *Session) Valid() bool {
+	return s != nil && time.Now().Before(s.ExpiresAt)
+}
+
+// Реализация Storage
+type MemoryStorage struct {
+	accounts         map[string]*Account
+	contractStorages map[string]map[string][]byte
+	mutex            sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		accounts:         make(map[string]*Account),
+		contractStorages: make(map[string]map[string][]byte),
+	}
+}
+
+func (s *MemoryStorage) SaveAccount(account *Account) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.accounts[account.ID] = account
+	return nil
+}
+
+func (s *MemoryStorage) LoadAccount(accountID string) (*Account, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	account, exists := s.accounts[accountID]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (s *MemoryStorage) GetAllAccounts() ([]*Account, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	accounts := make([]*Account, 0, len(s.accounts))
+	for _, account := range s.accounts {
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func (s *MemoryStorage) SaveAccounts(accounts []*Account) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, account := range accounts {
+		s.accounts[account.ID] = account
+	}
+	return nil
+}
+
+func (s *MemoryStorage) SaveContractStorage(accountID string, storage map[string][]byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	copied := make(map[string][]byte, len(storage))
+	for k, v := range storage {
+		copied[k] = v
+	}
+	s.contractStorages[accountID] = copied
+	return nil
+}
+
+func (s *MemoryStorage) LoadContractStorage(accountID string) (map[string][]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	storage, exists := s.contractStorages[accountID]
+	if !exists {
+		return make(map[string][]byte), nil
+	}
+
+	copied := make(map[string][]byte, len(storage))
+	for k, v := range storage {
+		copied[k] = v
+	}
+	return copied, nil
+}
+
+// FileStorage хранит каждый счет в отдельном JSON-файле на диске, так что
+// счета переживают перезапуск приложения.
+type FileStorage struct {
+	dir   string
+	mutex sync.RWMutex
+}
+
+// NewFileStorage создает файловое хранилище в каталоге dir, создавая его при необходимости.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог хранилища: %w", err)
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (s *FileStorage) accountPath(accountID string) string {
+	return filepath.Join(s.dir, accountID+".json")
+}
+
+func (s *FileStorage) contractStoragePath(accountID string) string {
+	return filepath.Join(s.dir, accountID+".storage.json")
+}
+
+func (s *FileStorage) SaveAccount(account *Account) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := json.MarshalIndent(account, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать счет: %w", err)
+	}
+
+	tmpPath := s.accountPath(account.ID) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("не удалось записать счет: %w", err)
+	}
+	return os.Rename(tmpPath, s.accountPath(account.ID))
+}
+
+// SaveAccounts сохраняет несколько счетов, сначала записывая все временные
+// файлы и лишь затем переименовывая их — это сужает окно, в котором счет
+// может оказаться сохранен наполовину при сбое.
+func (s *FileStorage) SaveAccounts(accounts []*Account) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tmpPaths := make([]string, len(accounts))
+	for i, account := range accounts {
+		data, err := json.MarshalIndent(account, "", "  ")
+		if err != nil {
+			return fmt.Errorf("не удалось сериализовать счет: %w", err)
+		}
+
+		tmpPath := s.accountPath(account.ID) + ".tmp"
+		if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+			return fmt.Errorf("не удалось записать счет: %w", err)
+		}
+		tmpPaths[i] = tmpPath
+	}
+
+	for i, account := range accounts {
+		if err := os.Rename(tmpPaths[i], s.accountPath(account.ID)); err != nil {
+			return fmt.Errorf("не удалось сохранить счет %s: %w", account.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *FileStorage) LoadAccount(accountID string) (*Account, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, err := os.ReadFile(s.accountPath(accountID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать счет: %w", err)
+	}
+
+	var account Account
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать счет: %w", err)
+	}
+	return &account, nil
+}
+
+func (s *FileStorage) GetAllAccounts() ([]*Account, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать каталог хранилища: %w", err)
+	}
+
+	accounts := make([]*Account, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		// Файлы <id>.storage.json — хранилища контрактов, а не счета.
+		if strings.HasSuffix(entry.Name(), ".storage.json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("не удалось прочитать счет %s: %w", entry.Name(), err)
+		}
+
+		var account Account
+		if err := json.Unmarshal(data, &account); err != nil {
+			return nil, fmt.Errorf("не удалось разобрать счет %s: %w", entry.Name(), err)
+		}
+		accounts = append(accounts, &account)
+	}
+	return accounts, nil
+}
+
+func (s *FileStorage) SaveContractStorage(accountID string, storage map[string][]byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := json.MarshalIndent(storage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать хранилище контракта: %w", err)
+	}
+
+	tmpPath := s.contractStoragePath(accountID) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("не удалось записать хранилище контракта: %w", err)
+	}
+	return os.Rename(tmpPath, s.contractStoragePath(accountID))
+}
+
+func (s *FileStorage) LoadContractStorage(accountID string) (map[string][]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	data, err := os.ReadFile(s.contractStoragePath(accountID))
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string][]byte), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать хранилище контракта: %w", err)
+	}
+
+	storage := make(map[string][]byte)
+	if err := json.Unmarshal(data, &storage); err != nil {
+		return nil, fmt.Errorf("не удалось разобрать хранилище контракта: %w", err)
+	}
+	return storage, nil
+}
+
+// Реализация AccountService
+type AccountServiceImpl struct {
+	account *Account
+	storage Storage
+	journal Journal
+	session *Session
+}
+
+// NewAccountService создает сервис счета. session может быть nil, если для счета
+// еще не подтвержден PIN (тогда Withdraw/Transfer будут отклонены).
+func NewAccountService(account *Account, storage Storage, journal Journal, session *Session) AccountService {
+	return &AccountServiceImpl{
+		account: account,
+		storage: storage,
+		journal: journal,
+		session: session,
+	}
+}
+
+func (s *AccountServiceImpl) Deposit(amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	s.account.Balance += amount
+	s.account.AddTransaction(Deposit, amount, "Пополнение счета")
+
+	return s.storage.SaveAccount(s.account)
+}
+
+func (s *AccountServiceImpl) Withdraw(amount float64) error {
+	if !s.session.Valid() {
+		return ErrSessionExpired
+	}
+
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	if s.account.Balance < amount {
+		return ErrInsufficientFunds
+	}
+
+	s.account.Balance -= amount
+	s.account.AddTransaction(Withdraw, amount, "Снятие средств")
+
+	return s.storage.SaveAccount(s.account)
+}
+
+func (s *AccountServiceImpl) Transfer(to *Account, amount float64) error {
+	if !s.session.Valid() {
+		return ErrSessionExpired
+	}
+
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	if s.account.Balance < amount {
+		return ErrInsufficientFunds
+	}
+
+	if s.account.ID == to.ID {
+		return ErrSameAccountTransfer
+	}
+
+	txID, err := s.journal.Begin()
+	if err != nil {
+		return fmt.Errorf("не удалось начать транзакцию: %w", err)
+	}
+
+	entry := JournalEntry{
+		Status:        JournalPending,
+		FromAccountID: s.account.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Timestamp:     time.Now(),
+	}
+	if err := s.journal.Append(txID, entry); err != nil {
+		return fmt.Errorf("не удалось записать транзакцию в журнал: %w", err)
+	}
+
+	tag := transferTag(txID)
+
+	// Применяем обе ноги перевода на копиях, а не на живых счетах: если
+	// SaveAccounts не пройдет, s.account/to должны остаться в точности такими,
+	// какими были до вызова Transfer, иначе любая последующая операция с тем
+	// же *Account (например, Deposit в CLI) зафиксирует баланс, которого
+	// никогда не было на диске.
+	fromClone := cloneAccount(s.account)
+	fromClone.Balance -= amount
+	fromClone.AddTransaction(Transfer, amount,
+		fmt.Sprintf("Перевод на счет %s %s", to.ID, tag))
+
+	toClone := cloneAccount(to)
+	toClone.Balance += amount
+	toClone.AddTransaction(Transfer, amount,
+		fmt.Sprintf("Перевод со счета %s %s", s.account.ID, tag))
+
+	// Сохраняем оба счета одним вызовом SaveAccounts
+	if err := s.storage.SaveAccounts([]*Account{fromClone, toClone}); err != nil {
+		return fmt.Errorf("не удалось сохранить счета: %w", err)
+	}
+
+	// Персист подтвержден — теперь можно безопасно перенести изменения на
+	// живые счета, на которые мог ссылаться вызывающий код (CLI).
+	*s.account = *fromClone
+	*to = *toClone
+
+	return s.journal.Commit(txID)
+}
+
+// gasPrice — сумма, списываемая с баланса вызывающего счета за единицу gas.
+const gasPrice = 0.0001
+
+// DeployContract создает новый счет-контракт с кодом code. Владельцем контракта
+// указывается владелец текущего счета.
+func (s *AccountServiceImpl) DeployContract(code []byte) (*Account, error) {
+	contract := NewAccount(s.account.OwnerName)
+	contract.Code = code
+	// Контракты не предназначены для интерактивного входа по PIN-коду (Call
+	// вызывается программно), поэтому задаем случайный, никому не известный
+	// PIN — иначе CheckPIN трактовал бы пустой PINHash как "вход без PIN" и
+	// позволил бы снять баланс контракта с произвольным PIN-кодом.
+	pin, err := generateContractPIN()
+	if err != nil {
+		return nil, err
+	}
+	contract.SetPIN(pin)
+
+	if err := s.storage.SaveAccount(contract); err != nil {
+		return nil, fmt.Errorf("не удалось сохранить контракт: %w", err)
+	}
+	if err := s.storage.SaveContractStorage(contract.ID, make(map[string][]byte)); err != nil {
+		return nil, fmt.Errorf("не удалось инициализировать хранилище контракта: %w", err)
+	}
+	return contract, nil
+}
+
+// Call исполняет код контракта to с входными данными input, списывая gas с
+// баланса текущего счета и возвращая неиспользованный остаток.
+func (s *AccountServiceImpl) Call(to *Account, input []byte, gas uint64) ([]byte, error) {
+	if !s.session.Valid() {
+		return nil, ErrSessionExpired
+	}
+
+	if len(to.Code) == 0 {
+		return nil, ErrNotAContract
+	}
+
+	fee := float64(gas) * gasPrice
+	if s.account.Balance < fee {
+		return nil, ErrInsufficientFunds
+	}
+	s.account.Balance -= fee
+
+	contractStorage, err := s.storage.LoadContractStorage(to.ID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить хранилище контракта: %w", err)
+	}
+
+	vm := NewVM(to, s.account, contractStorage, gas)
+	output, gasUsed, runErr := vm.Run(to.Code, input)
+
+	// Возвращаем неиспользованный gas вызывающему счету. Это происходит
+	// независимо от REVERT — gas платится за исполнение, а не за успех.
+	// При ErrOutOfGas gasUsed включает стоимость последнего опкода и может
+	// превышать gas — тогда возвращать нечего.
+	if gasUsed > gas {
+		gasUsed = gas
+	}
+	s.account.Balance += float64(gas-gasUsed) * gasPrice
+
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	// OpTransfer в контракте мутировал только песочницу VM (vm.contract/vm.caller
+	// balance), а не реальные счета — переносим итоговый сдвиг баланса теперь,
+	// когда известно, что исполнение завершилось успешно (не REVERT/ошибка).
+	initContractBalance, initCallerBalance := vm.InitialBalances()
+	contractBalance, callerBalance := vm.Balances()
+	to.Balance += contractBalance - initContractBalance
+	s.account.Balance += callerBalance - initCallerBalance
+
+	if err := s.storage.SaveContractStorage(to.ID, contractStorage); err != nil {
+		return nil, fmt.Errorf("не удалось сохранить хранилище контракта: %w", err)
+	}
+	if err := s.storage.SaveAccount(s.account); err != nil {
+		return nil, err
+	}
+	return output, s.storage.SaveAccount(to)
+}
+
+func (s *AccountServiceImpl) GetBalance() float64 {
+	return s.account.Balance
+}
+
+func (s *AccountServiceImpl) GetStatement() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Выписка по счету %s\n", s.account.ID))
+	sb.WriteString(fmt.Sprintf("Владелец: %s\n", s.account.OwnerName))
+	sb.WriteString(fmt.Sprintf("Текущий баланс: %.2f\n\n", s.account.Balance))
+	sb.WriteString("История транзакций:\n")
+
+	if len(s.account.Transactions) == 0 {
+		sb.WriteString("Транзакций нет\n")
+		return sb.String()
+	}
+
+	for _, tx := range s.account.Transactions {
+		sb.WriteString(fmt.Sprintf("- %s: %.2f (%s) - %s\n",
+			tx.Type, tx.Amount, tx.Timestamp.Format("02.01.2006 15:04:05"), tx.Description))
+	}
+
+	return sb.String()
+}
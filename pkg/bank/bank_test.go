@@ -0,0 +1,197 @@
+package bank
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFileStorageRoundTripsAccount(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage: %v", err)
+	}
+
+	account := NewAccount("alice")
+	account.SetPIN("1234")
+	account.Balance = 150
+	account.AddTransaction(Deposit, 150, "Пополнение счета")
+
+	if err := storage.SaveAccount(account); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	loaded, err := storage.LoadAccount(account.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount: %v", err)
+	}
+
+	if loaded.ID != account.ID || loaded.OwnerName != account.OwnerName {
+		t.Fatalf("загруженный счет не совпадает с сохраненным: %+v", loaded)
+	}
+	if loaded.Balance != account.Balance {
+		t.Errorf("Balance после round-trip = %v, хотим %v", loaded.Balance, account.Balance)
+	}
+	if len(loaded.Transactions) != 1 {
+		t.Errorf("Transactions после round-trip = %v, хотим 1 запись", loaded.Transactions)
+	}
+	if !loaded.CheckPIN("1234") {
+		t.Errorf("PINHash не пережил round-trip: CheckPIN(\"1234\") = false")
+	}
+	if loaded.CheckPIN("0000") {
+		t.Errorf("CheckPIN принял неверный PIN после round-trip")
+	}
+}
+
+func TestFileStorageGetAllAccountsListsSavedAccounts(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage: %v", err)
+	}
+
+	alice := NewAccount("alice")
+	bob := NewAccount("bob")
+	if err := storage.SaveAccounts([]*Account{alice, bob}); err != nil {
+		t.Fatalf("SaveAccounts: %v", err)
+	}
+
+	accounts, err := storage.GetAllAccounts()
+	if err != nil {
+		t.Fatalf("GetAllAccounts: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("GetAllAccounts вернул %d счетов, хотим 2", len(accounts))
+	}
+}
+
+func TestFileStorageLoadAccountNotFound(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage: %v", err)
+	}
+
+	if _, err := storage.LoadAccount("ACCMISSING"); err != ErrAccountNotFound {
+		t.Fatalf("LoadAccount несуществующего счета: ожидалась ErrAccountNotFound, получено %v", err)
+	}
+}
+
+func TestFileStorageContractStorageRoundTrips(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage: %v", err)
+	}
+
+	want := map[string][]byte{"key": []byte("value")}
+	if err := storage.SaveContractStorage("ACCCONTRACT", want); err != nil {
+		t.Fatalf("SaveContractStorage: %v", err)
+	}
+
+	got, err := storage.LoadContractStorage("ACCCONTRACT")
+	if err != nil {
+		t.Fatalf("LoadContractStorage: %v", err)
+	}
+	if string(got["key"]) != "value" {
+		t.Errorf("LoadContractStorage вернул %v, хотим %v", got, want)
+	}
+}
+
+func TestAccountSetPINHashesAndVerifies(t *testing.T) {
+	account := NewAccount("alice")
+	account.SetPIN("4242")
+
+	if len(account.PINHash) == 0 {
+		t.Fatalf("SetPIN не заполнил PINHash")
+	}
+	if string(account.PINHash) == "4242" {
+		t.Fatalf("PIN сохранен в открытом виде вместо хэша")
+	}
+	if !account.CheckPIN("4242") {
+		t.Errorf("CheckPIN(\"4242\") = false для верного PIN")
+	}
+	if account.CheckPIN("0000") {
+		t.Errorf("CheckPIN(\"0000\") = true для неверного PIN")
+	}
+}
+
+func TestAccountCheckPINAllowsAccessWithoutSetPIN(t *testing.T) {
+	account := NewAccount("alice")
+
+	if !account.CheckPIN("любой-пин") {
+		t.Errorf("счет без PINHash (например, из старого файла) должен проходить CheckPIN с любым вводом")
+	}
+}
+
+// failingSaveAccountsStorage оборачивает MemoryStorage и отклоняет
+// SaveAccounts, чтобы смоделировать сбой диска на этапе атомарного сохранения
+// обеих ног перевода.
+type failingSaveAccountsStorage struct {
+	*MemoryStorage
+}
+
+var errSaveAccountsFailed = errors.New("симулированный сбой SaveAccounts")
+
+func (s *failingSaveAccountsStorage) SaveAccounts(accounts []*Account) error {
+	return errSaveAccountsFailed
+}
+
+func TestTransferLeavesLiveAccountsUntouchedWhenSaveAccountsFails(t *testing.T) {
+	storage := &failingSaveAccountsStorage{MemoryStorage: NewMemoryStorage()}
+
+	from := NewAccount("alice")
+	from.Balance = 100
+	to := NewAccount("bob")
+	to.Balance = 0
+	if err := storage.MemoryStorage.SaveAccounts([]*Account{from, to}); err != nil {
+		t.Fatalf("SaveAccounts (setup): %v", err)
+	}
+
+	session := NewSession(time.Minute)
+	svc := NewAccountService(from, storage, NewMemoryJournal(), session)
+
+	if err := svc.Transfer(to, 40); !errors.Is(err, errSaveAccountsFailed) {
+		t.Fatalf("Transfer: ожидалась ошибка SaveAccounts, получено %v", err)
+	}
+
+	if from.Balance != 100 {
+		t.Errorf("баланс отправителя в памяти изменился после неудачного SaveAccounts: было 100, стало %v", from.Balance)
+	}
+	if to.Balance != 0 {
+		t.Errorf("баланс получателя в памяти изменился после неудачного SaveAccounts: было 0, стало %v", to.Balance)
+	}
+	if len(from.Transactions) != 0 {
+		t.Errorf("у отправителя осталась запись о несостоявшемся переводе: %v", from.Transactions)
+	}
+	if len(to.Transactions) != 0 {
+		t.Errorf("у получателя осталась запись о несостоявшемся переводе: %v", to.Transactions)
+	}
+
+	// Следующая успешная операция не должна зафиксировать на диске баланс,
+	// отравленный несостоявшимся переводом.
+	if err := svc.Deposit(1); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	stored, err := storage.LoadAccount(from.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount: %v", err)
+	}
+	if stored.Balance != 101 {
+		t.Errorf("после Deposit баланс на диске = %v, хотим 101 (100 + 1, без отмененного перевода)", stored.Balance)
+	}
+}
+
+func TestSessionValid(t *testing.T) {
+	var nilSession *Session
+	if nilSession.Valid() {
+		t.Errorf("nil-сессия не должна быть Valid")
+	}
+
+	expired := NewSession(-time.Minute)
+	if expired.Valid() {
+		t.Errorf("сессия с истекшим TTL не должна быть Valid")
+	}
+
+	active := NewSession(time.Minute)
+	if !active.Valid() {
+		t.Errorf("свежесозданная сессия с положительным TTL должна быть Valid")
+	}
+}
@@ -0,0 +1,379 @@
+package bank
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TxID идентифицирует одну транзакцию в журнале (WAL).
+type TxID string
+
+// JournalStatus — стадия транзакции в журнале.
+type JournalStatus string
+
+const (
+	JournalPending    JournalStatus = "PENDING"
+	JournalCommitted  JournalStatus = "COMMITTED"
+	JournalRolledBack JournalStatus = "ROLLED_BACK"
+)
+
+// JournalEntry — одна запись журнала. Для PENDING-записи заполнены обе ноги
+// перевода; COMMITTED/ROLLED_BACK-записи лишь отмечают исход транзакции TxID.
+type JournalEntry struct {
+	TxID          TxID
+	Status        JournalStatus
+	FromAccountID string
+	ToAccountID   string
+	Amount        float64
+	Timestamp     time.Time
+}
+
+// Journal — WAL (write-ahead log) для переводов между счетами: перед тем как
+// менять балансы, Transfer фиксирует намерение (PENDING), а после успешного
+// сохранения счетов — подтверждение (COMMITTED). Replay на старте находит
+// транзакции, застрявшие в PENDING, чтобы их можно было откатить.
+type Journal interface {
+	Begin() (TxID, error)
+	Append(id TxID, entry JournalEntry) error
+	Commit(id TxID) error
+	Rollback(id TxID) error
+	Replay(fn func(JournalEntry) error) error
+	// Compact отбрасывает записи уже завершенных (COMMITTED/ROLLED_BACK) транзакций.
+	Compact() error
+}
+
+func transferTag(txID TxID) string {
+	return fmt.Sprintf("[tx:%s]", txID)
+}
+
+func newTxID() TxID {
+	return TxID(fmt.Sprintf("JTX%d", time.Now().UnixNano()))
+}
+
+func resolvedTxIDs(entries []JournalEntry) map[TxID]bool {
+	resolved := make(map[TxID]bool)
+	for _, entry := range entries {
+		if entry.Status == JournalCommitted || entry.Status == JournalRolledBack {
+			resolved[entry.TxID] = true
+		}
+	}
+	return resolved
+}
+
+// MemoryJournal — журнал в памяти процесса. Полезен для демо-режима с
+// MemoryStorage, где восстановление после сбоя все равно невозможно.
+type MemoryJournal struct {
+	entries []JournalEntry
+	mutex   sync.Mutex
+}
+
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{}
+}
+
+func (j *MemoryJournal) Begin() (TxID, error) {
+	return newTxID(), nil
+}
+
+func (j *MemoryJournal) Append(id TxID, entry JournalEntry) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	entry.TxID = id
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+func (j *MemoryJournal) Commit(id TxID) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.entries = append(j.entries, JournalEntry{TxID: id, Status: JournalCommitted, Timestamp: time.Now()})
+	return nil
+}
+
+func (j *MemoryJournal) Rollback(id TxID) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.entries = append(j.entries, JournalEntry{TxID: id, Status: JournalRolledBack, Timestamp: time.Now()})
+	return nil
+}
+
+func (j *MemoryJournal) Replay(fn func(JournalEntry) error) error {
+	j.mutex.Lock()
+	entries := make([]JournalEntry, len(j.entries))
+	copy(entries, j.entries)
+	j.mutex.Unlock()
+
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *MemoryJournal) Compact() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	resolved := resolvedTxIDs(j.entries)
+	kept := make([]JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if !resolved[entry.TxID] {
+			kept = append(kept, entry)
+		}
+	}
+	j.entries = kept
+	return nil
+}
+
+// FileJournal пишет записи журнала как длиннопрефиксные JSON-записи в
+// append-only лог-файл, что позволяет перечитать их после перезапуска.
+type FileJournal struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileJournal открывает (создавая при необходимости) лог-файл журнала по пути path.
+func NewFileJournal(path string) (*FileJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть файл журнала: %w", err)
+	}
+	_ = f.Close()
+	return &FileJournal{path: path}, nil
+}
+
+func (j *FileJournal) appendRecord(entry JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать запись журнала: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть файл журнала: %w", err)
+	}
+	defer f.Close()
+
+	var lengthPrefix [4]byte
+	binary.BigEndian.PutUint32(lengthPrefix[:], uint32(len(data)))
+	if _, err := f.Write(lengthPrefix[:]); err != nil {
+		return fmt.Errorf("не удалось записать запись журнала: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("не удалось записать запись журнала: %w", err)
+	}
+	return nil
+}
+
+func (j *FileJournal) readEntries() ([]JournalEntry, error) {
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл журнала: %w", err)
+	}
+
+	var entries []JournalEntry
+	offset := 0
+	for offset < len(data) {
+		if offset+4 > len(data) {
+			return nil, fmt.Errorf("повреждена запись журнала: недостаточно байт длины")
+		}
+		length := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		offset += 4
+
+		if offset+length > len(data) {
+			return nil, fmt.Errorf("повреждена запись журнала: недостаточно данных записи")
+		}
+
+		var entry JournalEntry
+		if err := json.Unmarshal(data[offset:offset+length], &entry); err != nil {
+			return nil, fmt.Errorf("не удалось разобрать запись журнала: %w", err)
+		}
+		offset += length
+
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (j *FileJournal) Begin() (TxID, error) {
+	return newTxID(), nil
+}
+
+func (j *FileJournal) Append(id TxID, entry JournalEntry) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	entry.TxID = id
+	return j.appendRecord(entry)
+}
+
+func (j *FileJournal) Commit(id TxID) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.appendRecord(JournalEntry{TxID: id, Status: JournalCommitted, Timestamp: time.Now()})
+}
+
+func (j *FileJournal) Rollback(id TxID) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.appendRecord(JournalEntry{TxID: id, Status: JournalRolledBack, Timestamp: time.Now()})
+}
+
+func (j *FileJournal) Replay(fn func(JournalEntry) error) error {
+	j.mutex.Lock()
+	entries, err := j.readEntries()
+	j.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compact переписывает лог-файл, отбрасывая записи уже завершенных транзакций.
+func (j *FileJournal) Compact() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	entries, err := j.readEntries()
+	if err != nil {
+		return err
+	}
+
+	resolved := resolvedTxIDs(entries)
+	tmpPath := j.path + ".compact.tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл журнала: %w", err)
+	}
+
+	for _, entry := range entries {
+		if resolved[entry.TxID] {
+			continue
+		}
+		data, err := json.Marshal(entry)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("не удалось сериализовать запись журнала: %w", err)
+		}
+		var lengthPrefix [4]byte
+		binary.BigEndian.PutUint32(lengthPrefix[:], uint32(len(data)))
+		if _, err := f.Write(lengthPrefix[:]); err != nil {
+			f.Close()
+			return fmt.Errorf("не удалось записать запись журнала: %w", err)
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return fmt.Errorf("не удалось записать запись журнала: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("не удалось закрыть временный файл журнала: %w", err)
+	}
+
+	return os.Rename(tmpPath, j.path)
+}
+
+// ReplayJournal восстанавливает журнал на старте: любая транзакция, застрявшая
+// в PENDING без последующего COMMITTED, считается незавершенной и откатывается.
+func ReplayJournal(journal Journal, storage Storage) error {
+	pending := make(map[TxID]JournalEntry)
+	committed := make(map[TxID]bool)
+
+	err := journal.Replay(func(entry JournalEntry) error {
+		switch entry.Status {
+		case JournalPending:
+			pending[entry.TxID] = entry
+		case JournalCommitted:
+			committed[entry.TxID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать журнал: %w", err)
+	}
+
+	for txID, entry := range pending {
+		if committed[txID] {
+			continue
+		}
+		if err := rollbackTransfer(storage, entry); err != nil {
+			return fmt.Errorf("не удалось откатить транзакцию %s: %w", txID, err)
+		}
+		if err := journal.Rollback(txID); err != nil {
+			return fmt.Errorf("не удалось отметить откат транзакции %s: %w", txID, err)
+		}
+	}
+	return nil
+}
+
+// rollbackTransfer отменяет эффект незавершенного перевода: если счет уже
+// успел сохранить свою ногу перевода на диск до сбоя, она снимается.
+func rollbackTransfer(storage Storage, entry JournalEntry) error {
+	from, err := loadAccountOrNil(storage, entry.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := loadAccountOrNil(storage, entry.ToAccountID)
+	if err != nil {
+		return err
+	}
+
+	tag := transferTag(entry.TxID)
+	var toSave []*Account
+
+	if from != nil && removeTaggedTransaction(from, tag) {
+		from.Balance += entry.Amount
+		toSave = append(toSave, from)
+	}
+	if to != nil && removeTaggedTransaction(to, tag) {
+		to.Balance -= entry.Amount
+		toSave = append(toSave, to)
+	}
+
+	if len(toSave) == 0 {
+		return nil
+	}
+	return storage.SaveAccounts(toSave)
+}
+
+func loadAccountOrNil(storage Storage, accountID string) (*Account, error) {
+	account, err := storage.LoadAccount(accountID)
+	if errors.Is(err, ErrAccountNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func removeTaggedTransaction(account *Account, tag string) bool {
+	for i, tx := range account.Transactions {
+		if strings.Contains(tx.Description, tag) {
+			account.Transactions = append(account.Transactions[:i], account.Transactions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
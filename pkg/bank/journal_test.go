@@ -0,0 +1,119 @@
+package bank
+
+import "testing"
+
+// TestReplayJournalRollsBackUncommittedTransfer моделирует сбой между записью
+// PENDING-записи и COMMITTED: обе ноги перевода уже сохранены на диск, но
+// журнал не содержит COMMITTED для этой транзакции. ReplayJournal должен
+// откатить перевод при старте.
+func TestReplayJournalRollsBackUncommittedTransfer(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	from := NewAccount("alice")
+	from.Balance = 100
+	to := NewAccount("bob")
+	to.Balance = 0
+
+	if err := storage.SaveAccounts([]*Account{from, to}); err != nil {
+		t.Fatalf("SaveAccounts: %v", err)
+	}
+
+	journal := NewMemoryJournal()
+	txID, err := journal.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	entry := JournalEntry{
+		Status:        JournalPending,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        40,
+	}
+	if err := journal.Append(txID, entry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	// Применяем обе ноги перевода и сохраняем счета, как это делает Transfer,
+	// но "падаем" до journal.Commit(txID).
+	tag := transferTag(txID)
+	from.Balance -= 40
+	from.AddTransaction(Transfer, 40, "Перевод на счет "+to.ID+" "+tag)
+	to.Balance += 40
+	to.AddTransaction(Transfer, 40, "Перевод со счета "+from.ID+" "+tag)
+	if err := storage.SaveAccounts([]*Account{from, to}); err != nil {
+		t.Fatalf("SaveAccounts: %v", err)
+	}
+
+	if err := ReplayJournal(journal, storage); err != nil {
+		t.Fatalf("ReplayJournal: %v", err)
+	}
+
+	gotFrom, err := storage.LoadAccount(from.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount(from): %v", err)
+	}
+	gotTo, err := storage.LoadAccount(to.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount(to): %v", err)
+	}
+
+	if gotFrom.Balance != 100 {
+		t.Errorf("баланс отправителя после отката = %v, хотим 100", gotFrom.Balance)
+	}
+	if gotTo.Balance != 0 {
+		t.Errorf("баланс получателя после отката = %v, хотим 0", gotTo.Balance)
+	}
+	if len(gotFrom.Transactions) != 0 {
+		t.Errorf("у отправителя осталась запись об откаченном переводе: %v", gotFrom.Transactions)
+	}
+	if len(gotTo.Transactions) != 0 {
+		t.Errorf("у получателя осталась запись об откаченном переводе: %v", gotTo.Transactions)
+	}
+}
+
+// TestReplayJournalSkipsCommittedTransfer проверяет, что перевод с записью
+// COMMITTED в журнале не откатывается при Replay.
+func TestReplayJournalSkipsCommittedTransfer(t *testing.T) {
+	storage := NewMemoryStorage()
+
+	from := NewAccount("alice")
+	from.Balance = 60
+	to := NewAccount("bob")
+	to.Balance = 40
+
+	if err := storage.SaveAccounts([]*Account{from, to}); err != nil {
+		t.Fatalf("SaveAccounts: %v", err)
+	}
+
+	journal := NewMemoryJournal()
+	txID, err := journal.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+
+	entry := JournalEntry{
+		Status:        JournalPending,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        40,
+	}
+	if err := journal.Append(txID, entry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := journal.Commit(txID); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	if err := ReplayJournal(journal, storage); err != nil {
+		t.Fatalf("ReplayJournal: %v", err)
+	}
+
+	gotFrom, err := storage.LoadAccount(from.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount(from): %v", err)
+	}
+	if gotFrom.Balance != 60 {
+		t.Errorf("баланс отправителя изменился после Replay зафиксированной транзакции: %v", gotFrom.Balance)
+	}
+}
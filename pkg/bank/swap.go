@@ -0,0 +1,226 @@
+package bank
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Currency — метаданные одной зарегистрированной валюты.
+type Currency struct {
+	Symbol      string
+	Decimals    int
+	DisplayName string
+}
+
+// CurrencyRegistry хранит метаданные всех валют, с которыми умеет работать SwapService.
+type CurrencyRegistry struct {
+	mutex      sync.RWMutex
+	currencies map[string]Currency
+}
+
+// NewCurrencyRegistry создает реестр, в который уже включена базовая валюта (BaseCurrencySymbol).
+func NewCurrencyRegistry() *CurrencyRegistry {
+	r := &CurrencyRegistry{currencies: make(map[string]Currency)}
+	r.Register(Currency{Symbol: BaseCurrencySymbol, Decimals: 2, DisplayName: "Российский рубль"})
+	return r
+}
+
+// Register добавляет (или обновляет) валюту в реестре.
+func (r *CurrencyRegistry) Register(currency Currency) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.currencies[currency.Symbol] = currency
+}
+
+// Get возвращает метаданные валюты по символу.
+func (r *CurrencyRegistry) Get(symbol string) (Currency, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	currency, ok := r.currencies[symbol]
+	return currency, ok
+}
+
+// SwapProposal — предложение изменить курс обмена одной пары валют,
+// ожидающее подтверждения администратором.
+type SwapProposal struct {
+	ID       string
+	From     string
+	To       string
+	Rate     float64
+	Executed bool
+}
+
+// SwapConfig хранит действующие курсы обмена. Менять курсы можно только через
+// ProposeSwapRate/ExecuteSwapProposal — аналог governance-proposal подхода,
+// применяемого в модулях управления параметрами протокола.
+type SwapConfig struct {
+	mutex          sync.RWMutex
+	adminAccountID string
+	rates          map[string]map[string]float64
+	proposals      map[string]*SwapProposal
+	nextProposalID int
+}
+
+// NewSwapConfig создает пустой конфиг курсов, управляемый счетом adminAccountID.
+func NewSwapConfig(adminAccountID string) *SwapConfig {
+	return &SwapConfig{
+		adminAccountID: adminAccountID,
+		rates:          make(map[string]map[string]float64),
+		proposals:      make(map[string]*SwapProposal),
+	}
+}
+
+// authorizeAdmin проверяет, что actorAccountID — это настроенный при старте счет
+// администратора и что session подтверждает успешный вход в этот счет по PIN-коду.
+// Операции администрирования отклоняются, если счет администратора вообще не
+// настроен (пустой adminAccountID), чтобы не выдавать права управления курсами
+// любому, кто оставит поле ввода пустым.
+func (c *SwapConfig) authorizeAdmin(actorAccountID string, session *Session) error {
+	if c.adminAccountID == "" {
+		return ErrAdminNotConfigured
+	}
+	if actorAccountID != c.adminAccountID {
+		return ErrNotAdmin
+	}
+	if !session.Valid() {
+		return ErrSessionExpired
+	}
+	return nil
+}
+
+// Rate возвращает действующий курс обмена from -> to, если он установлен.
+func (c *SwapConfig) Rate(from, to string) (float64, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	rate, ok := c.rates[from][to]
+	return rate, ok
+}
+
+// ProposeSwapRate регистрирует предложение нового курса обмена от имени actorAccountID.
+// actorAccountID должен совпадать с настроенным администратором, а session —
+// быть действующей сессией, полученной после ввода его PIN-кода. Исполнить
+// предложение можно только через ExecuteSwapProposal.
+func (c *SwapConfig) ProposeSwapRate(actorAccountID string, session *Session, from, to string, rate float64) (string, error) {
+	if err := c.authorizeAdmin(actorAccountID, session); err != nil {
+		return "", err
+	}
+	if rate <= 0 {
+		return "", ErrInvalidSwapRate
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.nextProposalID++
+	id := fmt.Sprintf("PROP%d", c.nextProposalID)
+	c.proposals[id] = &SwapProposal{ID: id, From: from, To: to, Rate: rate}
+	return id, nil
+}
+
+// ExecuteSwapProposal применяет ранее предложенный курс, делая его действующим.
+// Требует того же подтверждения личности администратора, что и ProposeSwapRate.
+func (c *SwapConfig) ExecuteSwapProposal(actorAccountID string, session *Session, proposalID string) error {
+	if err := c.authorizeAdmin(actorAccountID, session); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	proposal, ok := c.proposals[proposalID]
+	if !ok {
+		return ErrProposalNotFound
+	}
+	if proposal.Executed {
+		return ErrProposalExecuted
+	}
+
+	if c.rates[proposal.From] == nil {
+		c.rates[proposal.From] = make(map[string]float64)
+	}
+	c.rates[proposal.From][proposal.To] = proposal.Rate
+	proposal.Executed = true
+	return nil
+}
+
+// Proposals возвращает снимок всех предложений по курсам.
+func (c *SwapConfig) Proposals() []*SwapProposal {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	proposals := make([]*SwapProposal, 0, len(c.proposals))
+	for _, p := range c.proposals {
+		copied := *p
+		proposals = append(proposals, &copied)
+	}
+	return proposals
+}
+
+// SwapService — операции обмена валют для одного счета.
+type SwapService interface {
+	Swap(from, to string, amount float64) error
+	SwapAll(from, to string) error
+}
+
+type SwapServiceImpl struct {
+	account  *Account
+	storage  Storage
+	registry *CurrencyRegistry
+	config   *SwapConfig
+	session  *Session
+}
+
+// NewSwapService создает сервис обмена валют для account.
+func NewSwapService(account *Account, storage Storage, registry *CurrencyRegistry, config *SwapConfig, session *Session) SwapService {
+	return &SwapServiceImpl{
+		account:  account,
+		storage:  storage,
+		registry: registry,
+		config:   config,
+		session:  session,
+	}
+}
+
+func (s *SwapServiceImpl) Swap(from, to string, amount float64) error {
+	if !s.session.Valid() {
+		return ErrSessionExpired
+	}
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	if _, ok := s.registry.Get(from); !ok {
+		return ErrUnknownCurrency
+	}
+	if _, ok := s.registry.Get(to); !ok {
+		return ErrUnknownCurrency
+	}
+
+	rate, ok := s.config.Rate(from, to)
+	if !ok || rate <= 0 {
+		return ErrSwapRateUnavailable
+	}
+
+	if s.account.balanceOf(from) < amount {
+		return ErrInsufficientFunds
+	}
+
+	converted := amount * rate
+
+	s.account.addBalance(from, -amount)
+	s.account.addBalance(to, converted)
+	s.account.AddTransaction(Swap, amount,
+		fmt.Sprintf("Обмен %.2f %s -> %.2f %s по курсу %.6f", amount, from, converted, to, rate))
+
+	return s.storage.SaveAccount(s.account)
+}
+
+func (s *SwapServiceImpl) SwapAll(from, to string) error {
+	balance := s.account.balanceOf(from)
+	if balance <= 0 {
+		return ErrInsufficientFunds
+	}
+	return s.Swap(from, to, balance)
+}
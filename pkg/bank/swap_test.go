@@ -0,0 +1,195 @@
+package bank
+
+import (
+	"testing"
+	"time"
+)
+
+func newSwapRegistry() *CurrencyRegistry {
+	registry := NewCurrencyRegistry()
+	registry.Register(Currency{Symbol: "USD", Decimals: 2, DisplayName: "Доллар США"})
+	return registry
+}
+
+// setSwapRate проставляет курс обмена через ProposeSwapRate/ExecuteSwapProposal
+// от имени администратора конфига; сами эти операции покрыты отдельными
+// тестами ниже.
+func setSwapRate(t *testing.T, config *SwapConfig, from, to string, rate float64) {
+	t.Helper()
+	session := NewSession(time.Minute)
+	proposalID, err := config.ProposeSwapRate(config.adminAccountID, session, from, to, rate)
+	if err != nil {
+		t.Fatalf("ProposeSwapRate: %v", err)
+	}
+	if err := config.ExecuteSwapProposal(config.adminAccountID, session, proposalID); err != nil {
+		t.Fatalf("ExecuteSwapProposal: %v", err)
+	}
+}
+
+func TestSwapConvertsAtConfiguredRate(t *testing.T) {
+	account := NewAccount("alice")
+	account.Balance = 100
+	storage := NewMemoryStorage()
+	if err := storage.SaveAccount(account); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	registry := newSwapRegistry()
+	config := NewSwapConfig("ACCADMIN")
+	setSwapRate(t, config, BaseCurrencySymbol, "USD", 0.01)
+
+	session := NewSession(time.Minute)
+	swapService := NewSwapService(account, storage, registry, config, session)
+
+	if err := swapService.Swap(BaseCurrencySymbol, "USD", 100); err != nil {
+		t.Fatalf("Swap: %v", err)
+	}
+
+	if account.Balance != 0 {
+		t.Errorf("баланс RUB после обмена = %v, хотим 0", account.Balance)
+	}
+	if got := account.balanceOf("USD"); got != 1 {
+		t.Errorf("баланс USD после обмена = %v, хотим 1", got)
+	}
+
+	found := false
+	for _, tx := range account.Transactions {
+		if tx.Type == Swap {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("обмен не записан в историю транзакций: %v", account.Transactions)
+	}
+}
+
+func TestSwapRejectsUnregisteredCurrency(t *testing.T) {
+	account := NewAccount("alice")
+	account.Balance = 100
+	storage := NewMemoryStorage()
+	registry := newSwapRegistry()
+	config := NewSwapConfig("ACCADMIN")
+	setSwapRate(t, config, BaseCurrencySymbol, "USD", 0.01)
+	session := NewSession(time.Minute)
+	swapService := NewSwapService(account, storage, registry, config, session)
+
+	if err := swapService.Swap(BaseCurrencySymbol, "XYZ", 10); err != ErrUnknownCurrency {
+		t.Fatalf("Swap в незарегистрированную валюту: ожидалась ErrUnknownCurrency, получено %v", err)
+	}
+	if err := swapService.Swap("XYZ", BaseCurrencySymbol, 10); err != ErrUnknownCurrency {
+		t.Fatalf("Swap из незарегистрированной валюты: ожидалась ErrUnknownCurrency, получено %v", err)
+	}
+}
+
+func TestSwapRejectsMissingOrInvalidRate(t *testing.T) {
+	account := NewAccount("alice")
+	account.Balance = 100
+	storage := NewMemoryStorage()
+	registry := newSwapRegistry()
+	config := NewSwapConfig("ACCADMIN")
+	session := NewSession(time.Minute)
+	swapService := NewSwapService(account, storage, registry, config, session)
+
+	if err := swapService.Swap(BaseCurrencySymbol, "USD", 10); err != ErrSwapRateUnavailable {
+		t.Fatalf("без установленного курса ожидалась ErrSwapRateUnavailable, получено %v", err)
+	}
+
+	// ProposeSwapRate сам отвергает неположительный курс, поэтому подделываем
+	// его напрямую в map, чтобы проверить защиту Swap от курса <= 0.
+	config.rates[BaseCurrencySymbol] = map[string]float64{"USD": 0}
+	if err := swapService.Swap(BaseCurrencySymbol, "USD", 10); err != ErrSwapRateUnavailable {
+		t.Fatalf("с нулевым курсом ожидалась ErrSwapRateUnavailable, получено %v", err)
+	}
+}
+
+func TestSwapAllDrainsFullBalance(t *testing.T) {
+	account := NewAccount("alice")
+	account.Balance = 250
+	storage := NewMemoryStorage()
+	registry := newSwapRegistry()
+	config := NewSwapConfig("ACCADMIN")
+	setSwapRate(t, config, BaseCurrencySymbol, "USD", 0.02)
+	session := NewSession(time.Minute)
+	swapService := NewSwapService(account, storage, registry, config, session)
+
+	if err := swapService.SwapAll(BaseCurrencySymbol, "USD"); err != nil {
+		t.Fatalf("SwapAll: %v", err)
+	}
+
+	if account.Balance != 0 {
+		t.Errorf("баланс RUB после SwapAll = %v, хотим 0", account.Balance)
+	}
+	if got := account.balanceOf("USD"); got != 5 {
+		t.Errorf("баланс USD после SwapAll = %v, хотим 5", got)
+	}
+}
+
+func TestSwapAllRejectsZeroBalance(t *testing.T) {
+	account := NewAccount("alice")
+	storage := NewMemoryStorage()
+	registry := newSwapRegistry()
+	config := NewSwapConfig("ACCADMIN")
+	setSwapRate(t, config, BaseCurrencySymbol, "USD", 0.02)
+	session := NewSession(time.Minute)
+	swapService := NewSwapService(account, storage, registry, config, session)
+
+	if err := swapService.SwapAll(BaseCurrencySymbol, "USD"); err != ErrInsufficientFunds {
+		t.Fatalf("SwapAll с нулевым балансом: ожидалась ErrInsufficientFunds, получено %v", err)
+	}
+}
+
+func TestProposeSwapRateRequiresAdminSession(t *testing.T) {
+	config := NewSwapConfig("ACCADMIN")
+
+	if _, err := config.ProposeSwapRate("ACCADMIN", nil, "RUB", "USD", 90); err != ErrSessionExpired {
+		t.Fatalf("без сессии ожидалась ErrSessionExpired, получено %v", err)
+	}
+
+	expired := NewSession(-time.Minute)
+	if _, err := config.ProposeSwapRate("ACCADMIN", expired, "RUB", "USD", 90); err != ErrSessionExpired {
+		t.Fatalf("с истекшей сессией ожидалась ErrSessionExpired, получено %v", err)
+	}
+
+	session := NewSession(time.Minute)
+	if _, err := config.ProposeSwapRate("ACCIMPOSTOR", session, "RUB", "USD", 90); err != ErrNotAdmin {
+		t.Fatalf("от имени чужого счета ожидалась ErrNotAdmin, получено %v", err)
+	}
+
+	if _, err := config.ProposeSwapRate("ACCADMIN", session, "RUB", "USD", 90); err != nil {
+		t.Fatalf("ProposeSwapRate с валидной сессией администратора: %v", err)
+	}
+}
+
+func TestAdminOperationsRefusedWhenAdminAccountUnconfigured(t *testing.T) {
+	config := NewSwapConfig("")
+	session := NewSession(time.Minute)
+
+	if _, err := config.ProposeSwapRate("", session, "RUB", "USD", 90); err != ErrAdminNotConfigured {
+		t.Fatalf("ожидалась ErrAdminNotConfigured, получено %v", err)
+	}
+	if err := config.ExecuteSwapProposal("", session, "PROP1"); err != ErrAdminNotConfigured {
+		t.Fatalf("ожидалась ErrAdminNotConfigured, получено %v", err)
+	}
+}
+
+func TestExecuteSwapProposalRequiresAdminSession(t *testing.T) {
+	config := NewSwapConfig("ACCADMIN")
+	session := NewSession(time.Minute)
+
+	proposalID, err := config.ProposeSwapRate("ACCADMIN", session, "RUB", "USD", 90)
+	if err != nil {
+		t.Fatalf("ProposeSwapRate: %v", err)
+	}
+
+	if err := config.ExecuteSwapProposal("ACCADMIN", nil, proposalID); err != ErrSessionExpired {
+		t.Fatalf("без сессии ожидалась ErrSessionExpired, получено %v", err)
+	}
+
+	if err := config.ExecuteSwapProposal("ACCADMIN", session, proposalID); err != nil {
+		t.Fatalf("ExecuteSwapProposal с валидной сессией: %v", err)
+	}
+
+	if _, ok := config.Rate("RUB", "USD"); !ok {
+		t.Fatalf("курс RUB->USD не был применён после исполнения предложения")
+	}
+}
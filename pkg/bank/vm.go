@@ -0,0 +1,259 @@
+package bank
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Опкоды минимальной стековой VM, исполняющей код счетов-контрактов.
+// Каждый опкод занимает один байт; OpPush дополнительно несет 8 байт
+// big-endian операнда.
+type Opcode byte
+
+const (
+	OpPush Opcode = iota
+	OpAdd
+	OpSub
+	OpMul
+	OpDiv
+	OpSload    // pop key; push storage[key]
+	OpSstore   // pop value, pop key; storage[key] = value
+	OpBalance  // push balance контракта (в копейках)
+	OpTransfer // pop amount; перевести amount с баланса контракта вызывающему счету
+	OpReturn   // pop value; завершить исполнение и вернуть value как output
+	OpRevert   // завершить исполнение с ошибкой, откатив изменения storage
+)
+
+var (
+	ErrOutOfGas       = errors.New("закончился gas")
+	ErrStackUnderflow = errors.New("недостаточно значений в стеке")
+	ErrReverted       = errors.New("исполнение контракта отменено (REVERT)")
+	ErrDivByZero      = errors.New("деление на ноль в контракте")
+)
+
+// Стоимость исполнения каждого опкода в единицах gas.
+const (
+	gasCostPush    uint64 = 3
+	gasCostArith   uint64 = 5
+	gasCostStorage uint64 = 20
+	gasCostMisc    uint64 = 2
+)
+
+// VM — минимальная стековая виртуальная машина для исполнения кода
+// счетов-контрактов. Одна VM исполняет один вызов Call.
+type VM struct {
+	contract *Account
+	caller   *Account
+	storage  map[string][]byte
+
+	// contractBalance и callerBalance — рабочие копии балансов, с которыми
+	// оперируют OpBalance/OpTransfer. Как и storage, который LoadContractStorage
+	// уже отдает VM копией, балансы не мутируют *Account напрямую: вызывающая
+	// сторона (AccountServiceImpl.Call) переносит их в реальные счета только
+	// при успешном (без revert и без ошибки) завершении Run.
+	contractBalance     float64
+	callerBalance       float64
+	initContractBalance float64
+	initCallerBalance   float64
+
+	stack   []uint64
+	gasUsed uint64
+	gasMax  uint64
+}
+
+// NewVM создает VM для вызова контракта contract вызывающим счетом caller,
+// ограниченную gasMax единицами gas.
+func NewVM(contract *Account, caller *Account, storage map[string][]byte, gasMax uint64) *VM {
+	return &VM{
+		contract:            contract,
+		caller:              caller,
+		storage:             storage,
+		gasMax:              gasMax,
+		contractBalance:     contract.Balance,
+		callerBalance:       caller.Balance,
+		initContractBalance: contract.Balance,
+		initCallerBalance:   caller.Balance,
+	}
+}
+
+// InitialBalances возвращает балансы контракта и вызывающего счета, с
+// которыми была создана VM — единственный источник истины для них, чтобы
+// AccountServiceImpl.Call не держал собственную копию в рассинхрон с тем,
+// что на самом деле получил NewVM.
+func (vm *VM) InitialBalances() (contract, caller float64) {
+	return vm.initContractBalance, vm.initCallerBalance
+}
+
+// Balances возвращает итоговые балансы контракта и вызывающего счета после
+// исполнения. Вызывающая сторона применяет их к реальным счетам только при
+// успешном завершении Run — при REVERT или любой другой ошибке эти значения
+// отбрасываются вместе со storage.
+func (vm *VM) Balances() (contract, caller float64) {
+	return vm.contractBalance, vm.callerBalance
+}
+
+// Run исполняет байткод code. Если во входных данных input есть хотя бы 8
+// байт, они интерпретируются как uint64 и кладутся на стек перед стартом
+// исполнения (аналог calldata).
+func (vm *VM) Run(code []byte, input []byte) ([]byte, uint64, error) {
+	if len(input) >= 8 {
+		vm.push(binary.BigEndian.Uint64(input[:8]))
+	}
+
+	for pc := 0; pc < len(code); pc++ {
+		op := Opcode(code[pc])
+
+		switch op {
+		case OpPush:
+			if err := vm.chargeGas(gasCostPush); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			if pc+8 >= len(code) {
+				return nil, vm.gasUsed, fmt.Errorf("OpPush: недостаточно байт операнда")
+			}
+			vm.push(binary.BigEndian.Uint64(code[pc+1 : pc+9]))
+			pc += 8
+
+		case OpAdd, OpSub, OpMul, OpDiv:
+			if err := vm.chargeGas(gasCostArith); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			b, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			a, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			result, err := applyArith(op, a, b)
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			vm.push(result)
+
+		case OpSload:
+			if err := vm.chargeGas(gasCostStorage); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			key, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			vm.push(vm.sload(key))
+
+		case OpSstore:
+			if err := vm.chargeGas(gasCostStorage); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			value, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			key, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			vm.sstore(key, value)
+
+		case OpBalance:
+			if err := vm.chargeGas(gasCostMisc); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			vm.push(uint64(vm.contractBalance * 100))
+
+		case OpTransfer:
+			if err := vm.chargeGas(gasCostMisc); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			amountCents, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			amount := float64(amountCents) / 100
+			if vm.contractBalance < amount {
+				return nil, vm.gasUsed, ErrInsufficientFunds
+			}
+			vm.contractBalance -= amount
+			vm.callerBalance += amount
+
+		case OpReturn:
+			if err := vm.chargeGas(gasCostMisc); err != nil {
+				return nil, vm.gasUsed, err
+			}
+			value, err := vm.pop()
+			if err != nil {
+				return nil, vm.gasUsed, err
+			}
+			output := make([]byte, 8)
+			binary.BigEndian.PutUint64(output, value)
+			return output, vm.gasUsed, nil
+
+		case OpRevert:
+			return nil, vm.gasUsed, ErrReverted
+
+		default:
+			return nil, vm.gasUsed, fmt.Errorf("неизвестный опкод: %d", op)
+		}
+	}
+
+	return nil, vm.gasUsed, nil
+}
+
+func (vm *VM) chargeGas(cost uint64) error {
+	vm.gasUsed += cost
+	if vm.gasUsed > vm.gasMax {
+		return ErrOutOfGas
+	}
+	return nil
+}
+
+func (vm *VM) push(value uint64) {
+	vm.stack = append(vm.stack, value)
+}
+
+func (vm *VM) pop() (uint64, error) {
+	if len(vm.stack) == 0 {
+		return 0, ErrStackUnderflow
+	}
+	value := vm.stack[len(vm.stack)-1]
+	vm.stack = vm.stack[:len(vm.stack)-1]
+	return value, nil
+}
+
+func (vm *VM) sload(key uint64) uint64 {
+	raw, ok := vm.storage[storageKey(key)]
+	if !ok || len(raw) < 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(raw)
+}
+
+func (vm *VM) sstore(key, value uint64) {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, value)
+	vm.storage[storageKey(key)] = raw
+}
+
+func storageKey(key uint64) string {
+	return fmt.Sprintf("%016x", key)
+}
+
+func applyArith(op Opcode, a, b uint64) (uint64, error) {
+	switch op {
+	case OpAdd:
+		return a + b, nil
+	case OpSub:
+		return a - b, nil
+	case OpMul:
+		return a * b, nil
+	case OpDiv:
+		if b == 0 {
+			return 0, ErrDivByZero
+		}
+		return a / b, nil
+	default:
+		return 0, fmt.Errorf("неарифметический опкод: %d", op)
+	}
+}
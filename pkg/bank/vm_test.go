@@ -0,0 +1,167 @@
+package bank
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func pushOp(value uint64) []byte {
+	buf := make([]byte, 9)
+	buf[0] = byte(OpPush)
+	binary.BigEndian.PutUint64(buf[1:], value)
+	return buf
+}
+
+func TestVMSstoreThenRevertDoesNotPersistStorage(t *testing.T) {
+	deployer := NewAccount("deployer")
+	deployer.SetPIN("1234")
+	session := NewSession(time.Minute)
+	storage := NewMemoryStorage()
+	if err := storage.SaveAccount(deployer); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	svc := NewAccountService(deployer, storage, NewMemoryJournal(), session)
+	if err := svc.Deposit(100); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+
+	// SSTORE(42, 7); REVERT
+	code := append(append(pushOp(42), pushOp(7)...), byte(OpSstore), byte(OpRevert))
+	contract, err := svc.DeployContract(code)
+	if err != nil {
+		t.Fatalf("DeployContract: %v", err)
+	}
+
+	if _, err := svc.Call(contract, nil, 1000); err != ErrReverted {
+		t.Fatalf("Call: ожидалась ErrReverted, получено %v", err)
+	}
+
+	contractStorage, err := storage.LoadContractStorage(contract.ID)
+	if err != nil {
+		t.Fatalf("LoadContractStorage: %v", err)
+	}
+	if _, exists := contractStorage[storageKey(42)]; exists {
+		t.Fatalf("REVERT не откатил запись в storage: %v", contractStorage)
+	}
+}
+
+func TestVMSstoreThenReturnPersistsStorage(t *testing.T) {
+	deployer := NewAccount("deployer")
+	session := NewSession(time.Minute)
+	storage := NewMemoryStorage()
+	if err := storage.SaveAccount(deployer); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	svc := NewAccountService(deployer, storage, NewMemoryJournal(), session)
+	if err := svc.Deposit(100); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+
+	// SSTORE(42, 7); PUSH(1); RETURN
+	code := append(append(pushOp(42), pushOp(7)...), byte(OpSstore))
+	code = append(code, pushOp(1)...)
+	code = append(code, byte(OpReturn))
+
+	contract, err := svc.DeployContract(code)
+	if err != nil {
+		t.Fatalf("DeployContract: %v", err)
+	}
+
+	if _, err := svc.Call(contract, nil, 1000); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+
+	contractStorage, err := storage.LoadContractStorage(contract.ID)
+	if err != nil {
+		t.Fatalf("LoadContractStorage: %v", err)
+	}
+	raw, exists := contractStorage[storageKey(42)]
+	if !exists || binary.BigEndian.Uint64(raw) != 7 {
+		t.Fatalf("ожидалось storage[42]=7, получено %v (exists=%v)", raw, exists)
+	}
+}
+
+func TestCallTransferThenRevertDoesNotLeakBalance(t *testing.T) {
+	deployer := NewAccount("deployer")
+	deployer.SetPIN("1234")
+	session := NewSession(time.Minute)
+	storage := NewMemoryStorage()
+	if err := storage.SaveAccount(deployer); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	svc := NewAccountService(deployer, storage, NewMemoryJournal(), session)
+	if err := svc.Deposit(100); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+
+	// PUSH(1000); TRANSFER; REVERT — переводит 10.00 со счета контракта
+	// вызывающему, а затем откатывает исполнение.
+	code := append(pushOp(1000), byte(OpTransfer), byte(OpRevert))
+	contract, err := svc.DeployContract(code)
+	if err != nil {
+		t.Fatalf("DeployContract: %v", err)
+	}
+	if err := svc.Transfer(contract, 50); err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	callerBalanceBefore := deployer.Balance
+	contractBalanceBefore := contract.Balance
+
+	if _, err := svc.Call(contract, nil, 1000); err != ErrReverted {
+		t.Fatalf("Call: ожидалась ErrReverted, получено %v", err)
+	}
+
+	if contract.Balance != contractBalanceBefore {
+		t.Errorf("REVERT не откатил баланс контракта: было %v, стало %v", contractBalanceBefore, contract.Balance)
+	}
+	// Вызывающий счет теряет только уплаченный gas, но не получает перевод.
+	if deployer.Balance >= callerBalanceBefore {
+		t.Errorf("баланс вызывающего счета не уменьшился на стоимость gas: было %v, стало %v", callerBalanceBefore, deployer.Balance)
+	}
+	if deployer.Balance <= callerBalanceBefore-1 {
+		t.Errorf("REVERT не должен был перенести перевод на баланс вызывающего счета: было %v, стало %v", callerBalanceBefore, deployer.Balance)
+	}
+
+	stored, err := storage.LoadAccount(contract.ID)
+	if err != nil {
+		t.Fatalf("LoadAccount(contract): %v", err)
+	}
+	if stored.Balance != contractBalanceBefore {
+		t.Errorf("откаченный перевод был персистирован: баланс контракта в хранилище = %v, хотим %v", stored.Balance, contractBalanceBefore)
+	}
+}
+
+func TestVMRunOutOfGas(t *testing.T) {
+	// Пять PUSH подряд стоят 5*gasCostPush, дадим gas только на четыре.
+	var code []byte
+	for i := 0; i < 5; i++ {
+		code = append(code, pushOp(1)...)
+	}
+
+	vm := NewVM(NewAccount("contract"), NewAccount("caller"), make(map[string][]byte), 4*gasCostPush)
+	if _, _, err := vm.Run(code, nil); err != ErrOutOfGas {
+		t.Fatalf("Run: ожидалась ErrOutOfGas, получено %v", err)
+	}
+}
+
+func TestVMArithmetic(t *testing.T) {
+	code := append(append(pushOp(3), pushOp(4)...), byte(OpAdd), byte(OpReturn))
+
+	vm := NewVM(NewAccount("contract"), NewAccount("caller"), make(map[string][]byte), 1000)
+	output, _, err := vm.Run(code, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := make([]byte, 8)
+	binary.BigEndian.PutUint64(want, 7)
+	if !bytes.Equal(output, want) {
+		t.Fatalf("ADD: ожидалось %v, получено %v", want, output)
+	}
+}